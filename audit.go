@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/webhook"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// AuditRecord is a single state-changing action taken against a vanity.
+type AuditRecord struct {
+	Action          string       `json:"action"` // "claim", "delete", "transfer" or "verify"
+	Vanity          string       `json:"vanity"`
+	PubID           string       `json:"pub_id"`
+	ActorID         snowflake.ID `json:"actor_id"`
+	PreviousOwnerID snowflake.ID `json:"previous_owner_id,omitempty"`
+	ResultStatus    string       `json:"result_status"` // "ok" or "error"
+	// CFResponseCode is the Cloudflare KV response status when a cloudflare
+	// backend write or delete came back with a non-200 status. It's 0
+	// otherwise: for successful actions, for the other backends, and for
+	// errors that never got a status from a write or delete.
+	CFResponseCode int `json:"cf_response_code,omitempty"`
+}
+
+// AuditSink records AuditRecords somewhere operators can search them.
+type AuditSink interface {
+	Record(ctx context.Context, record AuditRecord) error
+}
+
+// auditBackend selects an AuditSink implementation via the
+// AUDIT_SINK environment variable.
+type auditBackend string
+
+const (
+	auditBackendStdout      auditBackend = "stdout"
+	auditBackendJSONLines   auditBackend = "jsonlines"
+	auditBackendDiscordHook auditBackend = "discord_webhook"
+)
+
+// NewAuditSinkFromEnv builds the AuditSink selected by AUDIT_SINK,
+// defaulting to stdout.
+func NewAuditSinkFromEnv() (AuditSink, error) {
+	backend := auditBackend(os.Getenv("AUDIT_SINK"))
+	if backend == "" {
+		backend = auditBackendStdout
+	}
+	switch backend {
+	case auditBackendStdout:
+		return NewStdoutAuditSink(), nil
+	case auditBackendJSONLines:
+		path := os.Getenv("AUDIT_LOG_PATH")
+		if path == "" {
+			path = "audit.jsonl"
+		}
+		return NewJSONLinesAuditSink(path)
+	case auditBackendDiscordHook:
+		return NewDiscordWebhookAuditSinkFromEnv()
+	default:
+		return nil, fmt.Errorf("unknown AUDIT_SINK %q", backend)
+	}
+}
+
+// StdoutAuditSink writes each record as a JSON line to stdout.
+type StdoutAuditSink struct {
+	mu sync.Mutex
+}
+
+func NewStdoutAuditSink() *StdoutAuditSink {
+	return &StdoutAuditSink{}
+}
+
+func (s *StdoutAuditSink) Record(_ context.Context, record AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return json.NewEncoder(os.Stdout).Encode(record)
+}
+
+// JSONLinesAuditSink appends each record as a JSON line to a file on disk.
+type JSONLinesAuditSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewJSONLinesAuditSink(path string) (*JSONLinesAuditSink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+	return &JSONLinesAuditSink{file: f}, nil
+}
+
+func (s *JSONLinesAuditSink) Record(_ context.Context, record AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return json.NewEncoder(s.file).Encode(record)
+}
+
+// DiscordWebhookAuditSink posts each record to a Discord channel webhook,
+// using disgo's webhook REST client.
+type DiscordWebhookAuditSink struct {
+	client webhook.Client
+}
+
+// NewDiscordWebhookAuditSinkFromEnv builds a DiscordWebhookAuditSink from
+// AUDIT_WEBHOOK_ID and AUDIT_WEBHOOK_TOKEN.
+func NewDiscordWebhookAuditSinkFromEnv() (*DiscordWebhookAuditSink, error) {
+	webhookID, err := snowflake.Parse(os.Getenv("AUDIT_WEBHOOK_ID"))
+	if err != nil {
+		return nil, fmt.Errorf("parsing AUDIT_WEBHOOK_ID: %w", err)
+	}
+	token := os.Getenv("AUDIT_WEBHOOK_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("AUDIT_WEBHOOK_TOKEN is required for the discord_webhook audit sink")
+	}
+	return &DiscordWebhookAuditSink{client: webhook.New(webhookID, token)}, nil
+}
+
+func (s *DiscordWebhookAuditSink) Record(_ context.Context, record AuditRecord) error {
+	content, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshalling audit record: %w", err)
+	}
+	_, err = s.client.CreateMessage(discord.WebhookMessageCreate{
+		Content: fmt.Sprintf("```json\n%s\n```", content),
+	})
+	return err
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"os"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// StoreHTTPError wraps a failed Cloudflare KV response so callers that
+// care — namely audit logging — can pull the status code back out,
+// without the VanityStore interface itself needing to know about HTTP.
+type StoreHTTPError struct {
+	StatusCode int
+}
+
+func (e *StoreHTTPError) Error() string {
+	return fmt.Sprintf("cloudflare kv request failed with status %d", e.StatusCode)
+}
+
+// CloudflareStore is the original VanityStore backend: vanities are stored
+// as Cloudflare Workers KV entries, keyed by vanity, with the public user
+// id as the value and the owner id stashed in the KV metadata.
+type CloudflareStore struct {
+	httpClient  *http.Client
+	apiToken    string
+	metadataURL string
+	valuesURL   string
+}
+
+// NewCloudflareStore builds a CloudflareStore from the CF_API_TOKEN
+// environment variable and the account/namespace baked into
+// metadataApiURL/valuesApiURL.
+func NewCloudflareStore() *CloudflareStore {
+	return &CloudflareStore{
+		httpClient:  http.DefaultClient,
+		apiToken:    os.Getenv("CF_API_TOKEN"),
+		metadataURL: metadataApiURL,
+		valuesURL:   valuesApiURL,
+	}
+}
+
+func (s *CloudflareStore) Lookup(ctx context.Context, vanity string) (string, snowflake.ID, error) {
+	metaRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadataURL+vanity, nil)
+	if err != nil {
+		return "", 0, fmt.Errorf("building metadata request: %w", err)
+	}
+	metaRequest.Header.Add("Authorization", s.apiToken)
+
+	metaRs, err := s.httpClient.Do(metaRequest)
+	if err != nil {
+		return "", 0, fmt.Errorf("running metadata request: %w", err)
+	}
+	defer metaRs.Body.Close()
+
+	if metaRs.StatusCode == http.StatusNotFound {
+		return "", 0, ErrVanityNotFound
+	}
+	if metaRs.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("metadata request returned status %d", metaRs.StatusCode)
+	}
+
+	var response MetadataResponse
+	if err = json.NewDecoder(metaRs.Body).Decode(&response); err != nil {
+		return "", 0, fmt.Errorf("decoding metadata response: %w", err)
+	}
+
+	valueRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, s.valuesURL+vanity, nil)
+	if err != nil {
+		return "", 0, fmt.Errorf("building value request: %w", err)
+	}
+	valueRequest.Header.Add("Authorization", s.apiToken)
+
+	valueRs, err := s.httpClient.Do(valueRequest)
+	if err != nil {
+		return "", 0, fmt.Errorf("running value request: %w", err)
+	}
+	defer valueRs.Body.Close()
+
+	if valueRs.StatusCode == http.StatusNotFound {
+		return "", 0, ErrVanityNotFound
+	}
+	if valueRs.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("value request returned status %d", valueRs.StatusCode)
+	}
+	pubID := new(bytes.Buffer)
+	if _, err := pubID.ReadFrom(valueRs.Body); err != nil {
+		return "", 0, fmt.Errorf("reading value response: %w", err)
+	}
+
+	return pubID.String(), response.Result.ID, nil
+}
+
+func (s *CloudflareStore) Claim(ctx context.Context, vanity, pubID string, ownerID snowflake.ID) error {
+	buf := new(bytes.Buffer)
+	w := multipart.NewWriter(buf)
+	_ = w.WriteField("value", pubID)
+	_ = w.WriteField("metadata", fmt.Sprintf(`{"id":"%s"}`, ownerID))
+	_ = w.Close()
+
+	valueRequest, err := http.NewRequestWithContext(ctx, http.MethodPut, s.valuesURL+vanity, buf)
+	if err != nil {
+		return fmt.Errorf("building value request: %w", err)
+	}
+	valueRequest.Header.Add("Authorization", s.apiToken)
+	valueRequest.Header.Add("Content-Type", w.FormDataContentType())
+
+	valueRs, err := s.httpClient.Do(valueRequest)
+	if err != nil {
+		return fmt.Errorf("running value request: %w", err)
+	}
+	defer valueRs.Body.Close()
+
+	if valueRs.StatusCode != http.StatusOK {
+		return &StoreHTTPError{StatusCode: valueRs.StatusCode}
+	}
+	return nil
+}
+
+func (s *CloudflareStore) Release(ctx context.Context, vanity string, ownerID snowflake.ID) error {
+	_, currentOwner, err := s.Lookup(ctx, vanity)
+	if err != nil {
+		return err
+	}
+	if currentOwner != ownerID {
+		return ErrVanityNotFound
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.valuesURL+vanity, nil)
+	if err != nil {
+		return fmt.Errorf("building delete request: %w", err)
+	}
+	req.Header.Add("Authorization", s.apiToken)
+
+	rs, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("running delete request: %w", err)
+	}
+	defer rs.Body.Close()
+
+	if rs.StatusCode != http.StatusOK {
+		return &StoreHTTPError{StatusCode: rs.StatusCode}
+	}
+	return nil
+}
+
+// ListByOwner isn't supported by Cloudflare KV without listing every key
+// in the namespace and fetching its metadata, which is prohibitively
+// expensive for this backend. Use the Redis or SQLite backend if you need
+// this.
+func (s *CloudflareStore) ListByOwner(ctx context.Context, ownerID snowflake.ID) ([]Entry, error) {
+	return nil, fmt.Errorf("ListByOwner is not supported by the cloudflare backend")
+}
+
+type MetadataResponse struct {
+	Result struct {
+		ID snowflake.ID `json:"id"`
+	} `json:"result"`
+}
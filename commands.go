@@ -0,0 +1,60 @@
+package main
+
+import "github.com/disgoorg/disgo/discord"
+
+// commands are the application commands this bot registers on startup.
+var commands = []discord.ApplicationCommandCreate{
+	discord.SlashCommandCreate{
+		Name:        "vanity",
+		Description: "Manage your sb.ltn.fi vanity user id.",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "claim",
+				Description: "Claim a vanity for one of your public user ids.",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:        "vanity",
+						Description: "The vanity to claim.",
+						Required:    true,
+					},
+					discord.ApplicationCommandOptionString{
+						Name:        "sb_user_id",
+						Description: "Your sb.ltn.fi public user id.",
+						Required:    true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "list",
+				Description: "List the vanities you own.",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "delete",
+				Description: "Release a vanity you own.",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:        "vanity",
+						Description: "The vanity to release.",
+						Required:    true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "transfer",
+				Description: "Transfer a vanity you own to another user.",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:        "vanity",
+						Description: "The vanity to transfer.",
+						Required:    true,
+					},
+					discord.ApplicationCommandOptionUser{
+						Name:        "user",
+						Description: "The user to transfer the vanity to.",
+						Required:    true,
+					},
+				},
+			},
+		},
+	},
+}
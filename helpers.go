@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+// createFollowup sends an ephemeral followup message to a deferred
+// interaction, formatting s with a like fmt.Sprintf.
+func createFollowup(event *events.ApplicationCommandInteractionCreate, s string, a ...any) {
+	_, _ = event.Client().Rest().CreateFollowupMessage(event.ApplicationID(), event.Token(), discord.MessageCreate{
+		Content: fmt.Sprintf(s, a...),
+	})
+}
+
+// recordAudit sends record to b.audit, logging (but not surfacing to the
+// user) if the sink itself fails.
+func (b *Bot) recordAudit(ctx context.Context, record AuditRecord) {
+	if err := b.audit.Record(ctx, record); err != nil {
+		slog.Error("there was an error while recording an audit record", "error", err, "action", record.Action)
+	}
+}
+
+// cfResponseCode pulls the Cloudflare KV status code out of err, if it
+// came from the cloudflare backend and failed with one. It's 0 for every
+// other backend and for errors that never reached the store.
+func cfResponseCode(err error) int {
+	var httpErr *StoreHTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode
+	}
+	return 0
+}
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+)
+
+// fatal logs msg at error level with args as slog key-value pairs, then
+// exits the process.
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
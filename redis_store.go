@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore stores each vanity as a hash (vanity:{vanity} -> pub_id,
+// owner_id) plus a set per owner (owner:{ownerID} -> vanities) so
+// ListByOwner doesn't require scanning the whole keyspace.
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStoreFromEnv builds a RedisStore from REDIS_ADDR (default
+// "localhost:6379"), REDIS_PASSWORD and REDIS_DB.
+func NewRedisStoreFromEnv() (*RedisStore, error) {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+	db := 0
+	if v := os.Getenv("REDIS_DB"); v != "" {
+		if _, err := fmt.Sscanf(v, "%d", &db); err != nil {
+			return nil, fmt.Errorf("parsing REDIS_DB: %w", err)
+		}
+	}
+	return &RedisStore{client: redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: os.Getenv("REDIS_PASSWORD"),
+		DB:       db,
+	})}, nil
+}
+
+func vanityKey(vanity string) string {
+	return "vanity:" + vanity
+}
+
+func ownerKey(ownerID snowflake.ID) string {
+	return "owner:" + ownerID.String()
+}
+
+func (s *RedisStore) Lookup(ctx context.Context, vanity string) (string, snowflake.ID, error) {
+	values, err := s.client.HMGet(ctx, vanityKey(vanity), "pub_id", "owner_id").Result()
+	if err != nil {
+		return "", 0, fmt.Errorf("reading vanity hash: %w", err)
+	}
+	pubID, ok := values[0].(string)
+	if !ok {
+		return "", 0, ErrVanityNotFound
+	}
+	ownerIDStr, ok := values[1].(string)
+	if !ok {
+		return "", 0, ErrVanityNotFound
+	}
+	ownerID, err := snowflake.Parse(ownerIDStr)
+	if err != nil {
+		return "", 0, fmt.Errorf("parsing owner id: %w", err)
+	}
+	return pubID, ownerID, nil
+}
+
+func (s *RedisStore) Claim(ctx context.Context, vanity, pubID string, ownerID snowflake.ID) error {
+	if _, previousOwner, err := s.Lookup(ctx, vanity); err == nil && previousOwner != ownerID {
+		if err := s.client.SRem(ctx, ownerKey(previousOwner), vanity).Err(); err != nil {
+			return fmt.Errorf("removing vanity from previous owner's set: %w", err)
+		}
+	}
+	pipe := s.client.TxPipeline()
+	pipe.HSet(ctx, vanityKey(vanity), "pub_id", pubID, "owner_id", ownerID.String())
+	pipe.SAdd(ctx, ownerKey(ownerID), vanity)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("claiming vanity: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisStore) Release(ctx context.Context, vanity string, ownerID snowflake.ID) error {
+	_, currentOwner, err := s.Lookup(ctx, vanity)
+	if err != nil {
+		return err
+	}
+	if currentOwner != ownerID {
+		return ErrVanityNotFound
+	}
+	pipe := s.client.TxPipeline()
+	pipe.Del(ctx, vanityKey(vanity))
+	pipe.SRem(ctx, ownerKey(ownerID), vanity)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("releasing vanity: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisStore) ListByOwner(ctx context.Context, ownerID snowflake.ID) ([]Entry, error) {
+	vanities, err := s.client.SMembers(ctx, ownerKey(ownerID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("reading owner set: %w", err)
+	}
+	entries := make([]Entry, 0, len(vanities))
+	for _, vanity := range vanities {
+		pubID, owner, err := s.Lookup(ctx, vanity)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, Entry{Vanity: vanity, PubID: pubID, OwnerID: owner})
+	}
+	return entries, nil
+}
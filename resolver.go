@@ -0,0 +1,144 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"log/slog"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// runResolver starts the HTTP resolver service (-mode=api), exposing
+// read-only lookups against store to browsers and userscripts that can't
+// go through the Discord bot.
+func runResolver(store VanityStore) error {
+	addr := os.Getenv("API_ADDR")
+	if addr == "" {
+		addr = ":8080"
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v/", func(w http.ResponseWriter, r *http.Request) {
+		handleLookup(store, w, r, strings.TrimPrefix(r.URL.Path, "/v/"))
+	})
+	mux.HandleFunc("/u/", func(w http.ResponseWriter, r *http.Request) {
+		handleListByOwner(store, w, r, strings.TrimPrefix(r.URL.Path, "/u/"))
+	})
+	mux.HandleFunc("/go/", func(w http.ResponseWriter, r *http.Request) {
+		handleRedirect(store, w, r, strings.TrimPrefix(r.URL.Path, "/go/"))
+	})
+
+	handler := withRequestLogging(withBearerAuth(mux))
+
+	slog.Info("vanity resolver api listening", "addr", addr)
+	return http.ListenAndServe(addr, handler)
+}
+
+func handleLookup(store VanityStore, w http.ResponseWriter, r *http.Request, vanity string) {
+	if vanity == "" {
+		http.NotFound(w, r)
+		return
+	}
+	pubID, ownerID, err := store.Lookup(r.Context(), vanity)
+	if errors.Is(err, ErrVanityNotFound) {
+		http.Error(w, "vanity not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		slog.Error("resolver: error looking up a vanity", "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		PubID   string       `json:"pub_id"`
+		OwnerID snowflake.ID `json:"owner_id"`
+	}{PubID: pubID, OwnerID: ownerID})
+}
+
+func handleListByOwner(store VanityStore, w http.ResponseWriter, r *http.Request, ownerIDStr string) {
+	if ownerIDStr == "" {
+		http.NotFound(w, r)
+		return
+	}
+	ownerID, err := snowflake.Parse(ownerIDStr)
+	if err != nil {
+		http.Error(w, "invalid owner id", http.StatusBadRequest)
+		return
+	}
+	entries, err := store.ListByOwner(r.Context(), ownerID)
+	if err != nil {
+		slog.Error("resolver: error listing a user's vanities", "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+func handleRedirect(store VanityStore, w http.ResponseWriter, r *http.Request, vanity string) {
+	if vanity == "" {
+		http.NotFound(w, r)
+		return
+	}
+	pubID, _, err := store.Lookup(r.Context(), vanity)
+	if errors.Is(err, ErrVanityNotFound) {
+		http.Error(w, "vanity not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		slog.Error("resolver: error looking up a vanity", "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "https://sb.ltn.fi/userid/"+pubID, http.StatusFound)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// withBearerAuth requires a matching `Authorization: Bearer <token>` header
+// when API_BEARER_TOKEN is set. It's a no-op otherwise.
+func withBearerAuth(next http.Handler) http.Handler {
+	token := os.Getenv("API_BEARER_TOKEN")
+	if token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := r.Header.Get("Authorization")
+		want := "Bearer " + token
+		if subtle.ConstantTimeCompare([]byte(got), []byte(want)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withRequestLogging logs method, path, status and latency for every
+// request handled by the resolver.
+func withRequestLogging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		slog.Info("handled request", "method", r.Method, "path", r.URL.Path, "status", rec.status, "duration", time.Since(start))
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
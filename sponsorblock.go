@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// sponsorBlockAPIURL is the SponsorBlock API queried to attest ownership
+// of a public user id during claim verification. Overridable via
+// SB_API_URL for self-hosted or test instances.
+var sponsorBlockAPIURL = "https://sponsor.ajay.app/api"
+
+// sponsorBlockUsernameContains reports whether pubID's current SponsorBlock
+// username contains nonce. A user who can make that true controls the
+// account's settings, which is the proof of ownership the claim
+// verification flow is checking for.
+func sponsorBlockUsernameContains(ctx context.Context, pubID, nonce string) (bool, error) {
+	apiURL := sponsorBlockAPIURL
+	if v := os.Getenv("SB_API_URL"); v != "" {
+		apiURL = v
+	}
+
+	values, err := json.Marshal([]string{"userName"})
+	if err != nil {
+		return false, fmt.Errorf("marshalling userInfo values: %w", err)
+	}
+	reqURL := fmt.Sprintf("%s/userInfo?publicUserID=%s&values=%s", apiURL, url.QueryEscape(pubID), url.QueryEscape(string(values)))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("building userInfo request: %w", err)
+	}
+
+	rs, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("running userInfo request: %w", err)
+	}
+	defer rs.Body.Close()
+
+	if rs.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("userInfo request returned status %d", rs.StatusCode)
+	}
+
+	var response struct {
+		UserName string `json:"userName"`
+	}
+	if err := json.NewDecoder(rs.Body).Decode(&response); err != nil {
+		return false, fmt.Errorf("decoding userInfo response: %w", err)
+	}
+
+	return strings.Contains(response.UserName, nonce), nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/disgoorg/snowflake/v2"
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore is a self-contained VanityStore backend for running the bot
+// without any external services. It keeps a single table, vanities(vanity,
+// pub_id, owner_id).
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStoreFromEnv opens (creating if necessary) the SQLite database
+// at the path in VANITY_SQLITE_PATH, defaulting to "vanities.db" in the
+// working directory.
+func NewSQLiteStoreFromEnv() (*SQLiteStore, error) {
+	path := os.Getenv("VANITY_SQLITE_PATH")
+	if path == "" {
+		path = "vanities.db"
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite database: %w", err)
+	}
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS vanities (
+		vanity   TEXT PRIMARY KEY,
+		pub_id   TEXT NOT NULL,
+		owner_id TEXT NOT NULL
+	)`); err != nil {
+		return nil, fmt.Errorf("creating vanities table: %w", err)
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+func (s *SQLiteStore) Lookup(ctx context.Context, vanity string) (string, snowflake.ID, error) {
+	var pubID, ownerIDStr string
+	err := s.db.QueryRowContext(ctx, `SELECT pub_id, owner_id FROM vanities WHERE vanity = ?`, vanity).
+		Scan(&pubID, &ownerIDStr)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", 0, ErrVanityNotFound
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("querying vanity: %w", err)
+	}
+	ownerID, err := snowflake.Parse(ownerIDStr)
+	if err != nil {
+		return "", 0, fmt.Errorf("parsing owner id: %w", err)
+	}
+	return pubID, ownerID, nil
+}
+
+func (s *SQLiteStore) Claim(ctx context.Context, vanity, pubID string, ownerID snowflake.ID) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO vanities (vanity, pub_id, owner_id) VALUES (?, ?, ?)
+		ON CONFLICT(vanity) DO UPDATE SET pub_id = excluded.pub_id, owner_id = excluded.owner_id`,
+		vanity, pubID, ownerID.String())
+	if err != nil {
+		return fmt.Errorf("claiming vanity: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Release(ctx context.Context, vanity string, ownerID snowflake.ID) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM vanities WHERE vanity = ? AND owner_id = ?`, vanity, ownerID.String())
+	if err != nil {
+		return fmt.Errorf("releasing vanity: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("checking release result: %w", err)
+	}
+	if affected == 0 {
+		return ErrVanityNotFound
+	}
+	return nil
+}
+
+func (s *SQLiteStore) ListByOwner(ctx context.Context, ownerID snowflake.ID) ([]Entry, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT vanity, pub_id FROM vanities WHERE owner_id = ?`, ownerID.String())
+	if err != nil {
+		return nil, fmt.Errorf("querying owner's vanities: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []Entry
+	for rows.Next() {
+		var entry Entry
+		if err := rows.Scan(&entry.Vanity, &entry.PubID); err != nil {
+			return nil, fmt.Errorf("scanning vanity row: %w", err)
+		}
+		entry.OwnerID = ownerID
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func newTestSQLiteStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	t.Setenv("VANITY_SQLITE_PATH", ":memory:")
+	store, err := NewSQLiteStoreFromEnv()
+	if err != nil {
+		t.Fatalf("NewSQLiteStoreFromEnv: %v", err)
+	}
+	return store
+}
+
+func TestSQLiteStoreClaimAndLookup(t *testing.T) {
+	store := newTestSQLiteStore(t)
+	ctx := context.Background()
+
+	if err := store.Claim(ctx, "foo", "pub1", 1); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+
+	pubID, ownerID, err := store.Lookup(ctx, "foo")
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	if pubID != "pub1" || ownerID != 1 {
+		t.Fatalf("Lookup = (%q, %d), want (pub1, 1)", pubID, ownerID)
+	}
+}
+
+func TestSQLiteStoreLookupNotFound(t *testing.T) {
+	store := newTestSQLiteStore(t)
+	if _, _, err := store.Lookup(context.Background(), "missing"); !errors.Is(err, ErrVanityNotFound) {
+		t.Fatalf("Lookup err = %v, want ErrVanityNotFound", err)
+	}
+}
+
+func TestSQLiteStoreClaimOverwritesExisting(t *testing.T) {
+	store := newTestSQLiteStore(t)
+	ctx := context.Background()
+
+	if err := store.Claim(ctx, "foo", "pub1", 1); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if err := store.Claim(ctx, "foo", "pub2", 2); err != nil {
+		t.Fatalf("Claim (overwrite): %v", err)
+	}
+
+	pubID, ownerID, err := store.Lookup(ctx, "foo")
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	if pubID != "pub2" || ownerID != 2 {
+		t.Fatalf("Lookup = (%q, %d), want (pub2, 2)", pubID, ownerID)
+	}
+}
+
+func TestSQLiteStoreReleaseOwnerMismatch(t *testing.T) {
+	store := newTestSQLiteStore(t)
+	ctx := context.Background()
+
+	if err := store.Claim(ctx, "foo", "pub1", 1); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+
+	if err := store.Release(ctx, "foo", 2); !errors.Is(err, ErrVanityNotFound) {
+		t.Fatalf("Release by non-owner err = %v, want ErrVanityNotFound", err)
+	}
+
+	// The claim must still be intact after the rejected release.
+	_, ownerID, err := store.Lookup(ctx, "foo")
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	if ownerID != 1 {
+		t.Fatalf("Lookup ownerID = %d, want 1 (unchanged)", ownerID)
+	}
+}
+
+func TestSQLiteStoreReleaseByOwner(t *testing.T) {
+	store := newTestSQLiteStore(t)
+	ctx := context.Background()
+
+	if err := store.Claim(ctx, "foo", "pub1", 1); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if err := store.Release(ctx, "foo", 1); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if _, _, err := store.Lookup(ctx, "foo"); !errors.Is(err, ErrVanityNotFound) {
+		t.Fatalf("Lookup after release err = %v, want ErrVanityNotFound", err)
+	}
+}
+
+func TestSQLiteStoreListByOwner(t *testing.T) {
+	store := newTestSQLiteStore(t)
+	ctx := context.Background()
+
+	if err := store.Claim(ctx, "foo", "pub1", 1); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if err := store.Claim(ctx, "bar", "pub2", 1); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if err := store.Claim(ctx, "baz", "pub3", 2); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+
+	entries, err := store.ListByOwner(ctx, 1)
+	if err != nil {
+		t.Fatalf("ListByOwner: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("ListByOwner returned %d entries, want 2", len(entries))
+	}
+	for _, e := range entries {
+		if e.OwnerID != 1 {
+			t.Fatalf("entry %+v has OwnerID %d, want 1", e, e.OwnerID)
+		}
+	}
+}
+
+var _ VanityStore = (*SQLiteStore)(nil)
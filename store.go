@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// ErrVanityNotFound is returned by VanityStore implementations when a
+// lookup or release targets a vanity that has no claim on record.
+var ErrVanityNotFound = errors.New("vanity not found")
+
+// Entry is a single claimed vanity as returned by ListByOwner.
+type Entry struct {
+	Vanity  string
+	PubID   string
+	OwnerID snowflake.ID
+}
+
+// VanityStore persists the vanity -> (public user id, owner) mapping.
+// Implementations must be safe for concurrent use.
+type VanityStore interface {
+	// Lookup resolves a vanity to the public user id it points at and the
+	// Discord user that claimed it. It returns ErrVanityNotFound if the
+	// vanity hasn't been claimed.
+	Lookup(ctx context.Context, vanity string) (pubID string, ownerID snowflake.ID, err error)
+	// Claim associates vanity with pubID, owned by ownerID, overwriting any
+	// existing claim. Callers are expected to have already checked
+	// ownership via Lookup.
+	Claim(ctx context.Context, vanity, pubID string, ownerID snowflake.ID) error
+	// Release removes vanity's claim, provided it's owned by ownerID. It
+	// returns ErrVanityNotFound if the vanity isn't claimed by ownerID.
+	Release(ctx context.Context, vanity string, ownerID snowflake.ID) error
+	// ListByOwner returns every vanity claimed by ownerID.
+	ListByOwner(ctx context.Context, ownerID snowflake.ID) ([]Entry, error)
+}
+
+// storeBackend selects a VanityStore implementation via the
+// VANITY_STORE_BACKEND environment variable.
+type storeBackend string
+
+const (
+	storeBackendCloudflare storeBackend = "cloudflare"
+	storeBackendRedis      storeBackend = "redis"
+	storeBackendSQLite     storeBackend = "sqlite"
+)
+
+// NewStoreFromEnv builds the VanityStore selected by the
+// VANITY_STORE_BACKEND environment variable, defaulting to the Cloudflare
+// KV backend for backwards compatibility.
+func NewStoreFromEnv() (VanityStore, error) {
+	backend := storeBackend(os.Getenv("VANITY_STORE_BACKEND"))
+	if backend == "" {
+		backend = storeBackendCloudflare
+	}
+	switch backend {
+	case storeBackendRedis:
+		return NewRedisStoreFromEnv()
+	case storeBackendSQLite:
+		return NewSQLiteStoreFromEnv()
+	case storeBackendCloudflare:
+		return NewCloudflareStore(), nil
+	default:
+		return nil, errors.New("unknown VANITY_STORE_BACKEND")
+	}
+}
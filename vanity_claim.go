@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+func (b *Bot) onClaim(event *events.ApplicationCommandInteractionCreate, data discord.SlashCommandInteractionData) {
+	pubUserID := data.String("sb_user_id")
+	if !publicIDRegex.MatchString(pubUserID) {
+		_ = event.CreateMessage(discord.MessageCreate{
+			Content: "Provided user id is not a valid public user id.",
+			Flags:   discord.MessageFlagEphemeral,
+		})
+		return
+	}
+	vanity := strings.ToLower(data.String("vanity"))
+	if len(vanity) > 32 || !vanityRegex.MatchString(vanity) {
+		_ = event.CreateMessage(discord.MessageCreate{
+			Content: "Provided vanity is not in a valid format. Use letters and numbers only up to 32 characters.",
+			Flags:   discord.MessageFlagEphemeral,
+		})
+		return
+	}
+	_ = event.DeferCreateMessage(true)
+
+	ctx := context.Background()
+	userID := event.User().ID
+	_, ownerID, err := b.store.Lookup(ctx, vanity)
+	if err != nil && !errors.Is(err, ErrVanityNotFound) {
+		slog.Error("there was an error while looking up a vanity", "error", err)
+		return
+	}
+	if err == nil && ownerID != userID {
+		createFollowup(event, "This vanity is already taken by <@%d>.", ownerID)
+		return
+	}
+
+	verified, err := b.verification.IsVerified(ctx, userID, pubUserID)
+	if err != nil {
+		slog.Error("there was an error while checking verification status", "error", err)
+		return
+	}
+	if !verified {
+		b.startVerification(event, userID, pubUserID)
+		return
+	}
+
+	resultStatus := "ok"
+	claimErr := b.store.Claim(ctx, vanity, pubUserID, userID)
+	if claimErr != nil {
+		resultStatus = "error"
+		slog.Error("there was an error while claiming a vanity", "error", claimErr)
+	}
+	b.recordAudit(ctx, AuditRecord{
+		Action:          "claim",
+		Vanity:          vanity,
+		PubID:           pubUserID,
+		ActorID:         userID,
+		PreviousOwnerID: ownerID,
+		ResultStatus:    resultStatus,
+		CFResponseCode:  cfResponseCode(claimErr),
+	})
+	if resultStatus != "ok" {
+		return
+	}
+	createFollowup(event, "Vanity `%s` associated with user id [`%s`](https://sb.ltn.fi/userid/%[2]s) has been successfully added.", vanity, pubUserID)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+func (b *Bot) onDelete(event *events.ApplicationCommandInteractionCreate, data discord.SlashCommandInteractionData) {
+	vanity := strings.ToLower(data.String("vanity"))
+	_ = event.DeferCreateMessage(true)
+
+	ctx := context.Background()
+	userID := event.User().ID
+	_, ownerID, err := b.store.Lookup(ctx, vanity)
+	if errors.Is(err, ErrVanityNotFound) {
+		createFollowup(event, "Vanity `%s` isn't claimed by anyone.", vanity)
+		return
+	}
+	if err != nil {
+		slog.Error("there was an error while looking up a vanity", "error", err)
+		return
+	}
+	if ownerID != userID {
+		createFollowup(event, "Vanity `%s` is owned by <@%d>, so you can't release it.", vanity, ownerID)
+		return
+	}
+
+	resultStatus := "ok"
+	releaseErr := b.store.Release(ctx, vanity, userID)
+	if releaseErr != nil {
+		resultStatus = "error"
+		slog.Error("there was an error while releasing a vanity", "error", releaseErr)
+	}
+	b.recordAudit(ctx, AuditRecord{
+		Action:          "delete",
+		Vanity:          vanity,
+		ActorID:         userID,
+		PreviousOwnerID: ownerID,
+		ResultStatus:    resultStatus,
+		CFResponseCode:  cfResponseCode(releaseErr),
+	})
+	if resultStatus != "ok" {
+		return
+	}
+	createFollowup(event, "Vanity `%s` has been released.", vanity)
+}
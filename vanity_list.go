@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+const vanityListPageSize = 10
+
+// vanityListCustomIDPrefix identifies the pagination buttons created by
+// onList. The custom id encodes the owner (so a button press can't be used
+// to page through someone else's list) and the target page.
+const vanityListCustomIDPrefix = "vanity_list:"
+
+func (b *Bot) onList(event *events.ApplicationCommandInteractionCreate, _ discord.SlashCommandInteractionData) {
+	_ = event.DeferCreateMessage(true)
+
+	ownerID := event.User().ID
+	entries, err := b.store.ListByOwner(context.Background(), ownerID)
+	if err != nil {
+		slog.Error("there was an error while listing a user's vanities", "error", err)
+		return
+	}
+
+	content, components := renderVanityListPage(entries, ownerID, 0)
+	_, _ = event.Client().Rest().CreateFollowupMessage(event.ApplicationID(), event.Token(), discord.MessageCreate{
+		Content:    content,
+		Components: components,
+	})
+}
+
+func (b *Bot) onComponent(event *events.ComponentInteractionCreate) {
+	customID := event.Data.CustomID()
+	if !strings.HasPrefix(customID, vanityListCustomIDPrefix) {
+		return
+	}
+	ownerID, page, err := parseVanityListCustomID(customID)
+	if err != nil {
+		slog.Error("received a malformed vanity list custom id", "error", err)
+		return
+	}
+	if ownerID != event.User().ID {
+		_ = event.CreateMessage(discord.MessageCreate{
+			Content: "This isn't your list to page through.",
+			Flags:   discord.MessageFlagEphemeral,
+		})
+		return
+	}
+
+	entries, err := b.store.ListByOwner(context.Background(), ownerID)
+	if err != nil {
+		slog.Error("there was an error while listing a user's vanities", "error", err)
+		return
+	}
+
+	content, components := renderVanityListPage(entries, ownerID, page)
+	_ = event.UpdateMessage(discord.MessageUpdate{
+		Content:    &content,
+		Components: &components,
+	})
+}
+
+// renderVanityListPage formats page (0-indexed) of entries and, if there
+// are more pages, a row of prev/next buttons scoped to ownerID.
+func renderVanityListPage(entries []Entry, ownerID snowflake.ID, page int) (string, []discord.ContainerComponent) {
+	if len(entries) == 0 {
+		return "You don't have any claimed vanities.", nil
+	}
+
+	pageCount := (len(entries) + vanityListPageSize - 1) / vanityListPageSize
+	if page < 0 {
+		page = 0
+	}
+	if page >= pageCount {
+		page = pageCount - 1
+	}
+
+	start := page * vanityListPageSize
+	end := start + vanityListPageSize
+	if end > len(entries) {
+		end = len(entries)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Your vanities (page %d/%d):\n", page+1, pageCount)
+	for _, entry := range entries[start:end] {
+		fmt.Fprintf(&sb, "`%s` -> [`%s`](https://sb.ltn.fi/userid/%[2]s)\n", entry.Vanity, entry.PubID)
+	}
+
+	if pageCount <= 1 {
+		return sb.String(), nil
+	}
+
+	prev := discord.NewSecondaryButton("Previous", vanityListCustomID(ownerID, page-1))
+	prev.Disabled = page == 0
+	next := discord.NewSecondaryButton("Next", vanityListCustomID(ownerID, page+1))
+	next.Disabled = page == pageCount-1
+
+	return sb.String(), []discord.ContainerComponent{discord.NewActionRow(prev, next)}
+}
+
+func vanityListCustomID(ownerID snowflake.ID, page int) string {
+	return fmt.Sprintf("%s%s:%d", vanityListCustomIDPrefix, ownerID, page)
+}
+
+func parseVanityListCustomID(customID string) (snowflake.ID, int, error) {
+	parts := strings.Split(strings.TrimPrefix(customID, vanityListCustomIDPrefix), ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("expected 2 parts, got %d", len(parts))
+	}
+	ownerID, err := snowflake.Parse(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing owner id: %w", err)
+	}
+	page, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing page: %w", err)
+	}
+	return ownerID, page, nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+func (b *Bot) onTransfer(event *events.ApplicationCommandInteractionCreate, data discord.SlashCommandInteractionData) {
+	vanity := strings.ToLower(data.String("vanity"))
+	recipient := data.User("user")
+	_ = event.DeferCreateMessage(true)
+
+	ctx := context.Background()
+	userID := event.User().ID
+	pubID, ownerID, err := b.store.Lookup(ctx, vanity)
+	if errors.Is(err, ErrVanityNotFound) {
+		createFollowup(event, "Vanity `%s` isn't claimed by anyone.", vanity)
+		return
+	}
+	if err != nil {
+		slog.Error("there was an error while looking up a vanity", "error", err)
+		return
+	}
+	if ownerID != userID {
+		createFollowup(event, "Vanity `%s` is owned by <@%d>, so you can't transfer it.", vanity, ownerID)
+		return
+	}
+	if recipient.ID == userID {
+		createFollowup(event, "Vanity `%s` is already yours.", vanity)
+		return
+	}
+
+	resultStatus := "ok"
+	claimErr := b.store.Claim(ctx, vanity, pubID, recipient.ID)
+	if claimErr != nil {
+		resultStatus = "error"
+		slog.Error("there was an error while transferring a vanity", "error", claimErr)
+	}
+	b.recordAudit(ctx, AuditRecord{
+		Action:          "transfer",
+		Vanity:          vanity,
+		PubID:           pubID,
+		ActorID:         userID,
+		PreviousOwnerID: ownerID,
+		ResultStatus:    resultStatus,
+		CFResponseCode:  cfResponseCode(claimErr),
+	})
+	if resultStatus != "ok" {
+		return
+	}
+	createFollowup(event, "Vanity `%s` has been transferred to <@%d>.", vanity, recipient.ID)
+}
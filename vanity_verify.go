@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// startVerification issues a new claim challenge for (userID, pubUserID)
+// and DMs the user a nonce and a link to confirm it. Proof of ownership is
+// the user setting their SponsorBlock username to include the nonce,
+// which only someone signed into that account's settings can do; the
+// link just triggers the bot to check for it.
+func (b *Bot) startVerification(event *events.ApplicationCommandInteractionCreate, userID snowflake.ID, pubUserID string) {
+	ctx := context.Background()
+	nonce, err := b.verification.CreateChallenge(ctx, userID, pubUserID)
+	if err != nil {
+		slog.Error("there was an error while creating a verification challenge", "error", err)
+		return
+	}
+
+	url := verificationBaseURL() + "/verify/" + nonce
+	if err := dmUser(event, userID, fmt.Sprintf(
+		"To claim a vanity for public user id `%s`, prove you control it:\n"+
+			"1. On https://sb.ltn.fi, set your username to include the code `%s`.\n"+
+			"2. Open this link to confirm: %s\n"+
+			"This code expires in %s.",
+		pubUserID, nonce, url, verificationTTL)); err != nil {
+		slog.Error("there was an error while DMing a verification link", "error", err)
+		createFollowup(event, "I couldn't DM you a verification link — please enable DMs from server members and try again.")
+		return
+	}
+
+	createFollowup(event, "Check your DMs to verify you own public user id `%s`, then run `/vanity claim` again.", pubUserID)
+}
+
+func verificationBaseURL() string {
+	if url := os.Getenv("VERIFICATION_BASE_URL"); url != "" {
+		return url
+	}
+	return "http://localhost:8081"
+}
+
+func dmUser(event *events.ApplicationCommandInteractionCreate, userID snowflake.ID, content string) error {
+	channel, err := event.Client().Rest().CreateDMChannel(userID)
+	if err != nil {
+		return fmt.Errorf("creating dm channel: %w", err)
+	}
+	_, err = event.Client().Rest().CreateMessage(channel.ID(), discord.MessageCreate{Content: content})
+	if err != nil {
+		return fmt.Errorf("sending dm: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// verificationTTL is how long a claim challenge stays valid before it must
+// be requested again.
+const verificationTTL = 15 * time.Minute
+
+// verifiedTTL is how long a *confirmed* challenge keeps counting toward
+// IsVerified. It's much longer than verificationTTL so a user who already
+// proved ownership of a pub_user_id doesn't have to repeat the DM/SponsorBlock
+// dance for every vanity they claim on it.
+const verifiedTTL = 30 * 24 * time.Hour
+
+// ErrChallengeNotFound is returned when a nonce doesn't match any pending
+// or completed challenge.
+var ErrChallengeNotFound = fmt.Errorf("verification challenge not found")
+
+// VerificationStore tracks proof that a Discord user controls a given
+// sb.ltn.fi public user id, so claims can't be made on someone else's
+// behalf.
+type VerificationStore interface {
+	// CreateChallenge issues a new nonce for discordUserID claiming pubID,
+	// replacing any challenge already pending for that pair.
+	CreateChallenge(ctx context.Context, discordUserID snowflake.ID, pubID string) (nonce string, err error)
+	// Challenge looks up the (discordUserID, pubID) pair nonce was issued
+	// for, without confirming it. It returns ErrChallengeNotFound if the
+	// nonce is unknown or has expired.
+	Challenge(ctx context.Context, nonce string) (discordUserID snowflake.ID, pubID string, err error)
+	// Confirm marks the challenge for nonce as verified, extending its
+	// expiry to verifiedTTL, and returns the pair it was issued for. It
+	// returns ErrChallengeNotFound if the nonce is unknown or has expired.
+	Confirm(ctx context.Context, nonce string) (discordUserID snowflake.ID, pubID string, err error)
+	// IsVerified reports whether discordUserID has a confirmed, unexpired
+	// challenge for pubID.
+	IsVerified(ctx context.Context, discordUserID snowflake.ID, pubID string) (bool, error)
+}
+
+type verificationChallenge struct {
+	discordUserID snowflake.ID
+	pubID         string
+	confirmed     bool
+	expiresAt     time.Time
+}
+
+// InMemoryVerificationStore is a process-local VerificationStore backed by
+// a map. Challenges are lost on restart: a user who was mid-verification
+// just has to start over, and a user who was already verified has to
+// re-verify once, which is an acceptable tradeoff for not needing a
+// persistent backend here.
+type InMemoryVerificationStore struct {
+	mu         sync.Mutex
+	challenges map[string]*verificationChallenge
+}
+
+// NewInMemoryVerificationStore returns an empty InMemoryVerificationStore.
+func NewInMemoryVerificationStore() *InMemoryVerificationStore {
+	return &InMemoryVerificationStore{challenges: make(map[string]*verificationChallenge)}
+}
+
+func (s *InMemoryVerificationStore) CreateChallenge(_ context.Context, discordUserID snowflake.ID, pubID string) (string, error) {
+	nonce, err := randomNonce()
+	if err != nil {
+		return "", fmt.Errorf("generating nonce: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evictExpiredLocked()
+	for existing, challenge := range s.challenges {
+		if !challenge.confirmed && challenge.discordUserID == discordUserID && challenge.pubID == pubID {
+			delete(s.challenges, existing)
+		}
+	}
+	s.challenges[nonce] = &verificationChallenge{
+		discordUserID: discordUserID,
+		pubID:         pubID,
+		expiresAt:     time.Now().Add(verificationTTL),
+	}
+	return nonce, nil
+}
+
+func (s *InMemoryVerificationStore) Challenge(_ context.Context, nonce string) (snowflake.ID, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evictExpiredLocked()
+
+	challenge, ok := s.challenges[nonce]
+	if !ok {
+		return 0, "", ErrChallengeNotFound
+	}
+	return challenge.discordUserID, challenge.pubID, nil
+}
+
+func (s *InMemoryVerificationStore) Confirm(_ context.Context, nonce string) (snowflake.ID, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evictExpiredLocked()
+
+	challenge, ok := s.challenges[nonce]
+	if !ok {
+		return 0, "", ErrChallengeNotFound
+	}
+	challenge.confirmed = true
+	challenge.expiresAt = time.Now().Add(verifiedTTL)
+	return challenge.discordUserID, challenge.pubID, nil
+}
+
+func (s *InMemoryVerificationStore) IsVerified(_ context.Context, discordUserID snowflake.ID, pubID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evictExpiredLocked()
+
+	for _, challenge := range s.challenges {
+		if challenge.confirmed && challenge.discordUserID == discordUserID && challenge.pubID == pubID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// evictExpiredLocked drops expired challenges. Callers must hold s.mu.
+func (s *InMemoryVerificationStore) evictExpiredLocked() {
+	now := time.Now()
+	for nonce, challenge := range s.challenges {
+		if now.After(challenge.expiresAt) {
+			delete(s.challenges, nonce)
+		}
+	}
+}
+
+func randomNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
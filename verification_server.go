@@ -0,0 +1,62 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// runVerificationCallback serves the link DM'd to users during claim
+// verification. It runs alongside the bot (not the -mode=api resolver,
+// which is a separate process and can't see the in-memory challenge
+// state) so a confirmed challenge is immediately visible to onClaim.
+func runVerificationCallback(verification VerificationStore, audit AuditSink) error {
+	addr := os.Getenv("VERIFICATION_ADDR")
+	if addr == "" {
+		addr = ":8081"
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/verify/", func(w http.ResponseWriter, r *http.Request) {
+		nonce := strings.TrimPrefix(r.URL.Path, "/verify/")
+		if nonce == "" {
+			http.NotFound(w, r)
+			return
+		}
+		ctx := r.Context()
+
+		_, pubID, err := verification.Challenge(ctx, nonce)
+		if err != nil {
+			http.Error(w, "this verification link is invalid or has expired", http.StatusNotFound)
+			return
+		}
+
+		attested, err := sponsorBlockUsernameContains(ctx, pubID, nonce)
+		if err != nil {
+			slog.Error("there was an error while checking sponsorblock ownership", "error", err)
+			http.Error(w, "couldn't reach the SponsorBlock api to check ownership, try again shortly", http.StatusBadGateway)
+			return
+		}
+		if !attested {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = w.Write([]byte("Couldn't find the code " + nonce + " in your SponsorBlock username yet. Set it on https://sb.ltn.fi, then reload this page."))
+			return
+		}
+
+		discordUserID, confirmedPubID, err := verification.Confirm(ctx, nonce)
+		if err != nil {
+			http.Error(w, "this verification link is invalid or has expired", http.StatusNotFound)
+			return
+		}
+		if err := audit.Record(ctx, AuditRecord{Action: "verify", PubID: confirmedPubID, ActorID: discordUserID, ResultStatus: "ok"}); err != nil {
+			slog.Error("there was an error while recording an audit record", "error", err, "action", "verify")
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("Ownership verified! Head back to Discord and run /vanity claim again."))
+	})
+
+	slog.Info("verification callback listening", "addr", addr)
+	return http.ListenAndServe(addr, mux)
+}
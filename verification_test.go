@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVerificationConfirmFlow(t *testing.T) {
+	store := NewInMemoryVerificationStore()
+	ctx := context.Background()
+
+	nonce, err := store.CreateChallenge(ctx, 1, "pub1")
+	if err != nil {
+		t.Fatalf("CreateChallenge: %v", err)
+	}
+
+	verified, err := store.IsVerified(ctx, 1, "pub1")
+	if err != nil {
+		t.Fatalf("IsVerified: %v", err)
+	}
+	if verified {
+		t.Fatal("IsVerified = true before Confirm, want false")
+	}
+
+	discordUserID, pubID, err := store.Confirm(ctx, nonce)
+	if err != nil {
+		t.Fatalf("Confirm: %v", err)
+	}
+	if discordUserID != 1 || pubID != "pub1" {
+		t.Fatalf("Confirm = (%d, %q), want (1, pub1)", discordUserID, pubID)
+	}
+
+	verified, err = store.IsVerified(ctx, 1, "pub1")
+	if err != nil {
+		t.Fatalf("IsVerified: %v", err)
+	}
+	if !verified {
+		t.Fatal("IsVerified = false after Confirm, want true")
+	}
+}
+
+func TestVerificationConfirmUnknownNonce(t *testing.T) {
+	store := NewInMemoryVerificationStore()
+	if _, _, err := store.Confirm(context.Background(), "nonexistent"); !errors.Is(err, ErrChallengeNotFound) {
+		t.Fatalf("Confirm err = %v, want ErrChallengeNotFound", err)
+	}
+}
+
+func TestVerificationPendingChallengeExpires(t *testing.T) {
+	store := NewInMemoryVerificationStore()
+	ctx := context.Background()
+
+	nonce, err := store.CreateChallenge(ctx, 1, "pub1")
+	if err != nil {
+		t.Fatalf("CreateChallenge: %v", err)
+	}
+	store.challenges[nonce].expiresAt = time.Now().Add(-time.Minute)
+
+	if _, _, err := store.Confirm(ctx, nonce); !errors.Is(err, ErrChallengeNotFound) {
+		t.Fatalf("Confirm of expired nonce err = %v, want ErrChallengeNotFound", err)
+	}
+}
+
+func TestVerificationConfirmedChallengeOutlivesChallengeTTL(t *testing.T) {
+	store := NewInMemoryVerificationStore()
+	ctx := context.Background()
+
+	nonce, err := store.CreateChallenge(ctx, 1, "pub1")
+	if err != nil {
+		t.Fatalf("CreateChallenge: %v", err)
+	}
+	if _, _, err := store.Confirm(ctx, nonce); err != nil {
+		t.Fatalf("Confirm: %v", err)
+	}
+
+	// Simulate the short verificationTTL window passing; a confirmed
+	// challenge should still count as verified well past that point.
+	store.challenges[nonce].expiresAt = store.challenges[nonce].expiresAt.Add(-(verificationTTL + time.Minute))
+
+	verified, err := store.IsVerified(ctx, 1, "pub1")
+	if err != nil {
+		t.Fatalf("IsVerified: %v", err)
+	}
+	if !verified {
+		t.Fatal("IsVerified = false for a confirmed challenge past the original verificationTTL, want true")
+	}
+}
+
+func TestCreateChallengeReplacesPendingChallenge(t *testing.T) {
+	store := NewInMemoryVerificationStore()
+	ctx := context.Background()
+
+	first, err := store.CreateChallenge(ctx, 1, "pub1")
+	if err != nil {
+		t.Fatalf("CreateChallenge: %v", err)
+	}
+	second, err := store.CreateChallenge(ctx, 1, "pub1")
+	if err != nil {
+		t.Fatalf("CreateChallenge: %v", err)
+	}
+
+	if _, _, err := store.Challenge(ctx, first); !errors.Is(err, ErrChallengeNotFound) {
+		t.Fatalf("Challenge(first) err = %v, want ErrChallengeNotFound (evicted)", err)
+	}
+	if _, _, err := store.Challenge(ctx, second); err != nil {
+		t.Fatalf("Challenge(second): %v", err)
+	}
+}
+
+func TestCreateChallengeDoesNotEvictConfirmedChallenge(t *testing.T) {
+	store := NewInMemoryVerificationStore()
+	ctx := context.Background()
+
+	nonce, err := store.CreateChallenge(ctx, 1, "pub1")
+	if err != nil {
+		t.Fatalf("CreateChallenge: %v", err)
+	}
+	if _, _, err := store.Confirm(ctx, nonce); err != nil {
+		t.Fatalf("Confirm: %v", err)
+	}
+
+	// Requesting another claim on the same pair shouldn't clobber the
+	// already-confirmed verification.
+	if _, err := store.CreateChallenge(ctx, 1, "pub1"); err != nil {
+		t.Fatalf("CreateChallenge: %v", err)
+	}
+
+	verified, err := store.IsVerified(ctx, 1, "pub1")
+	if err != nil {
+		t.Fatalf("IsVerified: %v", err)
+	}
+	if !verified {
+		t.Fatal("IsVerified = false after a new pending challenge was created, want true")
+	}
+}